@@ -0,0 +1,70 @@
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+// DefaultCacheDir is where downloaded market data is persisted so repeat
+// backtest runs don't rehit MarketAPI.
+const DefaultCacheDir = "var/data"
+
+// cacheEntry is what's actually persisted: the data alongside the range
+// it covers, so a later run asking for a wider or shifted range can tell
+// the cache doesn't satisfy it instead of silently returning a subset.
+type cacheEntry struct {
+	From time.Time          `json:"from"`
+	To   time.Time          `json:"to"`
+	Data []*model.KlineData `json:"data"`
+}
+
+// cacheKey identifies one cached kline series.
+func cacheKey(exchangeName string, pair model.CurrencyPair, period model.KlinePeriod) string {
+	return fmt.Sprintf("%s_%s_%d", exchangeName, pair.String(), period)
+}
+
+func cachePath(dir, exchangeName string, pair model.CurrencyPair, period model.KlinePeriod) string {
+	return filepath.Join(dir, cacheKey(exchangeName, pair, period)+".json")
+}
+
+// loadKlinesCache reads a previously cached kline series, returning
+// ok=false if nothing is cached yet or the cached range doesn't fully
+// cover [from, to].
+func loadKlinesCache(dir, exchangeName string, pair model.CurrencyPair, period model.KlinePeriod, from, to time.Time) (data []*model.KlineData, ok bool) {
+	raw, err := ioutil.ReadFile(cachePath(dir, exchangeName, pair, period))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, false
+	}
+
+	if entry.From.After(from) || entry.To.Before(to) {
+		return nil, false
+	}
+
+	return filterRange(entry.Data, from, to), true
+}
+
+// saveKlinesCache persists a kline series, and the [from, to] range it
+// covers, for reuse by later runs.
+func saveKlinesCache(dir, exchangeName string, pair model.CurrencyPair, period model.KlinePeriod, from, to time.Time, data []*model.KlineData) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(cacheEntry{From: from, To: to, Data: data})
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(cachePath(dir, exchangeName, pair, period), raw, 0o644)
+}
@@ -0,0 +1,142 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Akagi201/cryptotrader/exchange"
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+func day(n int) time.Time {
+	return time.Date(2020, 1, n, 0, 0, 0, 0, time.UTC)
+}
+
+func TestLoadKlinesCacheMissesOnWiderRange(t *testing.T) {
+	dir := t.TempDir()
+	pair := model.CurrencyPair{Base: "btc", Quote: "cny"}
+
+	var data []*model.KlineData
+	for n := 1; n <= 10; n++ {
+		data = append(data, &model.KlineData{Time: day(n), Close: float64(n)})
+	}
+	if err := saveKlinesCache(dir, "test", pair, model.KLINE_1DAY, day(1), day(10), data); err != nil {
+		t.Fatalf("saveKlinesCache: %v", err)
+	}
+
+	if _, ok := loadKlinesCache(dir, "test", pair, model.KLINE_1DAY, day(1), day(10)); !ok {
+		t.Fatalf("want cache hit for the exact range it was saved with")
+	}
+
+	if _, ok := loadKlinesCache(dir, "test", pair, model.KLINE_1DAY, day(1), day(31)); ok {
+		t.Fatalf("want cache miss when the requested range extends beyond what's cached")
+	}
+
+	if _, ok := loadKlinesCache(dir, "test", pair, model.KLINE_1DAY, day(0), day(10)); ok {
+		t.Fatalf("want cache miss when the requested range starts before what's cached")
+	}
+}
+
+func TestLoadKlinesCacheMissesWithNothingCached(t *testing.T) {
+	dir := t.TempDir()
+	pair := model.CurrencyPair{Base: "btc", Quote: "cny"}
+
+	if _, ok := loadKlinesCache(dir, "test", pair, model.KLINE_1DAY, day(1), day(10)); ok {
+		t.Fatalf("want cache miss with nothing ever saved")
+	}
+}
+
+func TestHistoricalSourceRefetchesWiderRange(t *testing.T) {
+	dir := t.TempDir()
+	pair := model.CurrencyPair{Base: "btc", Quote: "cny"}
+
+	var all []*model.KlineData
+	for n := 1; n <= 31; n++ {
+		all = append(all, &model.KlineData{Time: day(n), Close: float64(n)})
+	}
+
+	ex := &fakeKlineExchange{data: all}
+	source := NewHistoricalSource(ex, dir)
+
+	narrow, err := source.Klines(pair, model.KLINE_1DAY, day(1), day(10))
+	if err != nil {
+		t.Fatalf("Klines (narrow): %v", err)
+	}
+	if len(narrow) != 10 {
+		t.Fatalf("want 10 candles, got %d", len(narrow))
+	}
+	if calls := ex.calls; calls != 1 {
+		t.Fatalf("want 1 upstream call, got %d", calls)
+	}
+
+	wide, err := source.Klines(pair, model.KLINE_1DAY, day(1), day(31))
+	if err != nil {
+		t.Fatalf("Klines (wide): %v", err)
+	}
+	if len(wide) != 31 {
+		t.Fatalf("want the cache to be recognized as stale and the full 31-day range refetched, got %d candles", len(wide))
+	}
+	if calls := ex.calls; calls != 2 {
+		t.Fatalf("want the wider range to trigger a second upstream call, got %d", calls)
+	}
+}
+
+// fakeKlineExchange serves GetKline from an in-memory slice, paginating
+// by the requested size and the "since" optional parameter the way a
+// real exchange would.
+type fakeKlineExchange struct {
+	data  []*model.KlineData
+	calls int
+}
+
+func (f *fakeKlineExchange) GetName() string { return "fake" }
+
+func (f *fakeKlineExchange) GetKline(pair model.CurrencyPair, period model.KlinePeriod, size int, opts ...model.OptionalParameter) (*model.Kline, error) {
+	f.calls++
+
+	since := int64(0)
+	if len(opts) > 0 {
+		if v, ok := opts[0]["since"]; ok {
+			since, _ = v.(int64)
+		}
+	}
+
+	var out []*model.KlineData
+	for _, kd := range f.data {
+		if kd.Time.UnixNano()/int64(time.Millisecond) < since {
+			continue
+		}
+		out = append(out, kd)
+		if len(out) >= size {
+			break
+		}
+	}
+
+	return &model.Kline{Symbol: pair.String(), Data: out}, nil
+}
+
+func (f *fakeKlineExchange) GetTicker(pair model.CurrencyPair) (*model.Ticker, error) {
+	return nil, nil
+}
+
+func (f *fakeKlineExchange) GetOrderBook(pair model.CurrencyPair, size int, merge float64) (*model.OrderBook, error) {
+	return nil, nil
+}
+
+func (f *fakeKlineExchange) GetTrades(pair model.CurrencyPair, since int) (*model.Trades, error) {
+	return nil, nil
+}
+
+func (f *fakeKlineExchange) PlaceOrder(pair model.CurrencyPair, price, amount float64, side string, opts ...exchange.OrderOption) (*model.Order, error) {
+	return nil, nil
+}
+
+func (f *fakeKlineExchange) CancelOrder(pair model.CurrencyPair, id string) error {
+	return nil
+}
+
+func (f *fakeKlineExchange) GetAccount() (*model.Account, error) {
+	return nil, nil
+}
+
+var _ exchange.Exchange = (*fakeKlineExchange)(nil)
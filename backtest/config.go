@@ -0,0 +1,37 @@
+// Package backtest replays cached historical klines through a simulated
+// matching engine that implements exchange.Exchange, so a Strategy
+// written against a live exchange can be pointed at history unchanged.
+package backtest
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the backtest run configuration, shaped like bbgo/qbtrade's
+// YAML: a time range, the symbols to replay, and one simulated account
+// per exchange.
+type Config struct {
+	StartTime time.Time                `yaml:"startTime"`
+	EndTime   time.Time                `yaml:"endTime"`
+	Symbols   []string                 `yaml:"symbols"`
+	Accounts  map[string]AccountConfig `yaml:"accounts"`
+}
+
+// AccountConfig seeds the Simulator's balances and fee schedule for one
+// exchange.
+type AccountConfig struct {
+	MakerFeeRate float64            `yaml:"makerFeeRate"`
+	TakerFeeRate float64            `yaml:"takerFeeRate"`
+	Balances     map[string]float64 `yaml:"balances"`
+}
+
+// LoadConfig parses a YAML backtest config.
+func LoadConfig(data []byte) (*Config, error) {
+	cfg := new(Config)
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
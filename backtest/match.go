@@ -0,0 +1,122 @@
+package backtest
+
+import (
+	"fmt"
+
+	"github.com/Akagi201/cryptotrader/exchange"
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+// PlaceOrder reserves funds for a limit order and fills it immediately as
+// a taker if the current bar already crosses the limit price; otherwise
+// it rests until a later bar crosses it (see matchRestingOrders).
+func (s *Simulator) PlaceOrder(pair model.CurrencyPair, price, amount float64, side string, opts ...exchange.OrderOption) (*model.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reserve(pair, price, amount, side); err != nil {
+		return nil, err
+	}
+
+	order := &model.Order{
+		ID:        s.newOrderID(),
+		Base:      pair.Base,
+		Quote:     pair.Quote,
+		Side:      side,
+		Price:     price,
+		Amount:    amount,
+		Status:    "open",
+		OrderTime: s.now(pair),
+	}
+
+	if bar, ok := s.lastBar(pair); ok && crosses(bar, price, side) {
+		s.fill(order, s.takerFeeRate)
+	} else {
+		s.orders[order.ID] = order
+	}
+
+	return order, nil
+}
+
+// CancelOrder releases the reserved funds for a still-open order.
+func (s *Simulator) CancelOrder(pair model.CurrencyPair, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[id]
+	if !ok {
+		return fmt.Errorf("backtest: no such open order %s", id)
+	}
+
+	s.release(order)
+	order.Status = "canceled"
+	delete(s.orders, id)
+	return nil
+}
+
+// reserve checks and deducts the funds a new order needs up front, the
+// same way a real exchange holds balance before accepting an order.
+func (s *Simulator) reserve(pair model.CurrencyPair, price, amount float64, side string) error {
+	if side == "sell" {
+		if s.balances[pair.Base] < amount {
+			return fmt.Errorf("backtest: insufficient %s balance", pair.Base)
+		}
+		s.balances[pair.Base] -= amount
+		return nil
+	}
+
+	cost := price * amount
+	if s.balances[pair.Quote] < cost {
+		return fmt.Errorf("backtest: insufficient %s balance", pair.Quote)
+	}
+	s.balances[pair.Quote] -= cost
+	return nil
+}
+
+// release returns a canceled order's reserved funds.
+func (s *Simulator) release(order *model.Order) {
+	if order.Side == "sell" {
+		s.balances[order.Base] += order.Amount
+	} else {
+		s.balances[order.Quote] += order.Price * order.Amount
+	}
+}
+
+// fill settles order against the reserved funds, crediting the other
+// side of the trade net of feeRate.
+func (s *Simulator) fill(order *model.Order, feeRate float64) {
+	notional := order.Price * order.Amount
+
+	if order.Side == "sell" {
+		s.balances[order.Quote] += notional * (1 - feeRate)
+	} else {
+		s.balances[order.Base] += order.Amount * (1 - feeRate)
+	}
+
+	order.DealAmount = order.Amount
+	order.Status = "filled"
+}
+
+// matchRestingOrders fills every open order for pair that kd's range
+// crosses, in price-time order like a real limit order book would.
+func (s *Simulator) matchRestingOrders(pair model.CurrencyPair, kd *model.KlineData) {
+	for id, order := range s.orders {
+		if order.Base != pair.Base || order.Quote != pair.Quote {
+			continue
+		}
+		if crosses(kd, order.Price, order.Side) {
+			s.fill(order, s.makerFeeRate)
+			delete(s.orders, id)
+		}
+	}
+}
+
+// crosses reports whether bar's range reaches a resting limit order's
+// price: a buy fills when price dips to or below it, a sell when price
+// rises to or above it.
+func crosses(bar *model.KlineData, price float64, side string) bool {
+	if side == "sell" {
+		return bar.High >= price
+	}
+	return bar.Low <= price
+}
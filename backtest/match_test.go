@@ -0,0 +1,129 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+func testPair() model.CurrencyPair {
+	return model.CurrencyPair{Base: "btc", Quote: "cny"}
+}
+
+func newTestSimulator(btc, cny float64) *Simulator {
+	return NewSimulator("test", AccountConfig{
+		TakerFeeRate: 0.001,
+		MakerFeeRate: 0.001,
+		Balances:     map[string]float64{"btc": btc, "cny": cny},
+	})
+}
+
+func TestPlaceOrderReservesAndReleasesBalance(t *testing.T) {
+	s := newTestSimulator(0, 10000)
+	pair := testPair()
+
+	order, err := s.PlaceOrder(pair, 100, 10, "buy")
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if order.Status != "open" {
+		t.Fatalf("want order resting with no bar fed yet, got status %q", order.Status)
+	}
+	if got := s.balances["cny"]; got != 9000 {
+		t.Fatalf("want 1000 cny reserved, balance = %v", got)
+	}
+
+	if err := s.CancelOrder(pair, order.ID); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if got := s.balances["cny"]; got != 10000 {
+		t.Fatalf("want reserved cny released on cancel, balance = %v", got)
+	}
+}
+
+func TestPlaceOrderFillsImmediatelyAsTaker(t *testing.T) {
+	s := newTestSimulator(0, 10000)
+	pair := testPair()
+
+	s.FeedKline(pair, &model.KlineData{Time: time.Unix(0, 0), Open: 100, High: 110, Low: 90, Close: 100})
+
+	order, err := s.PlaceOrder(pair, 105, 10, "buy")
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if order.Status != "filled" {
+		t.Fatalf("want immediate taker fill since bar already crosses 105, got status %q", order.Status)
+	}
+	if order.DealAmount != 10 {
+		t.Fatalf("want full fill, dealt %v", order.DealAmount)
+	}
+	if _, open := s.orders[order.ID]; open {
+		t.Fatalf("filled order should not remain in the open order book")
+	}
+}
+
+func TestMatchRestingOrdersFillsOnLaterBar(t *testing.T) {
+	s := newTestSimulator(0, 10000)
+	pair := testPair()
+
+	s.FeedKline(pair, &model.KlineData{Time: time.Unix(0, 0), Open: 100, High: 100, Low: 100, Close: 100})
+
+	order, err := s.PlaceOrder(pair, 90, 10, "buy")
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if order.Status != "open" {
+		t.Fatalf("want order resting, bar doesn't cross 90 yet, got status %q", order.Status)
+	}
+
+	s.FeedKline(pair, &model.KlineData{Time: time.Unix(60, 0), Open: 95, High: 95, Low: 85, Close: 90})
+
+	if order.Status != "filled" {
+		t.Fatalf("want resting buy filled once a later bar dips to 90, got status %q", order.Status)
+	}
+	if _, open := s.orders[order.ID]; open {
+		t.Fatalf("filled resting order should have been removed from the open order book")
+	}
+}
+
+func TestReserveRejectsInsufficientBalance(t *testing.T) {
+	s := newTestSimulator(0, 100)
+	pair := testPair()
+
+	if _, err := s.PlaceOrder(pair, 100, 10, "buy"); err == nil {
+		t.Fatalf("want insufficient-balance error for a 1000 cny buy against 100 cny balance")
+	}
+
+	if _, err := s.PlaceOrder(pair, 100, 1, "sell"); err == nil {
+		t.Fatalf("want insufficient-balance error for a sell with no btc balance")
+	}
+}
+
+func TestGetKlineReturnsRollingWindow(t *testing.T) {
+	s := newTestSimulator(0, 10000)
+	pair := testPair()
+
+	for i := 0; i < 5; i++ {
+		s.FeedKline(pair, &model.KlineData{Time: time.Unix(int64(i), 0), Close: float64(i)})
+	}
+
+	kline, err := s.GetKline(pair, model.KLINE_1MIN, 2)
+	if err != nil {
+		t.Fatalf("GetKline: %v", err)
+	}
+	if len(kline.Data) != 2 {
+		t.Fatalf("want last 2 bars, got %d", len(kline.Data))
+	}
+	if kline.Data[0].Close != 3 || kline.Data[1].Close != 4 {
+		t.Fatalf("want the two most recent bars in feed order, got %v, %v", kline.Data[0].Close, kline.Data[1].Close)
+	}
+
+	all, err := s.GetKline(pair, model.KLINE_1MIN, 0)
+	if err != nil {
+		t.Fatalf("GetKline: %v", err)
+	}
+	if len(all.Data) != 5 {
+		t.Fatalf("want every fed bar when size <= 0, got %d", len(all.Data))
+	}
+}
@@ -0,0 +1,85 @@
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+// bar is one symbol's candle, tagged so the merged replay can report
+// which pair it belongs to.
+type bar struct {
+	pair model.CurrencyPair
+	data *model.KlineData
+}
+
+// Backtest replays cached historical klines for cfg.Symbols through a
+// Simulator and a Strategy.
+type Backtest struct {
+	cfg       *Config
+	source    *HistoricalSource
+	simulator *Simulator
+	period    model.KlinePeriod
+}
+
+// New builds a Backtest for exchangeName using cfg, sourcing candles
+// through source and simulating fills with exchangeName's AccountConfig.
+func New(cfg *Config, exchangeName string, source *HistoricalSource, period model.KlinePeriod) *Backtest {
+	return &Backtest{
+		cfg:       cfg,
+		source:    source,
+		simulator: NewSimulator(exchangeName, cfg.Accounts[exchangeName]),
+		period:    period,
+	}
+}
+
+// Simulator returns the underlying exchange.Exchange a Strategy can trade
+// against while the backtest runs.
+func (b *Backtest) Simulator() *Simulator {
+	return b.simulator
+}
+
+// Run loads every configured symbol's candles, merges them into
+// chronological order, and feeds them to the simulator and strategy one
+// bar at a time.
+func (b *Backtest) Run(strategy Strategy) error {
+	var bars []bar
+
+	for _, symbol := range b.cfg.Symbols {
+		pair, err := parseSymbol(symbol)
+		if err != nil {
+			return err
+		}
+
+		data, err := b.source.Klines(pair, b.period, b.cfg.StartTime, b.cfg.EndTime)
+		if err != nil {
+			return err
+		}
+
+		for _, kd := range data {
+			bars = append(bars, bar{pair: pair, data: kd})
+		}
+	}
+
+	sort.SliceStable(bars, func(i, j int) bool {
+		return bars[i].data.Time.Before(bars[j].data.Time)
+	})
+
+	for _, bb := range bars {
+		b.simulator.FeedKline(bb.pair, bb.data)
+		strategy.OnKline(bb.pair, bb.data)
+	}
+
+	return nil
+}
+
+// parseSymbol turns a "base_quote" config symbol into a CurrencyPair.
+func parseSymbol(symbol string) (model.CurrencyPair, error) {
+	parts := strings.SplitN(symbol, "_", 2)
+	if len(parts) != 2 {
+		return model.CurrencyPair{}, fmt.Errorf("backtest: invalid symbol %q, want base_quote", symbol)
+	}
+	return model.CurrencyPair{Base: parts[0], Quote: parts[1]}, nil
+}
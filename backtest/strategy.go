@@ -0,0 +1,12 @@
+package backtest
+
+import "github.com/Akagi201/cryptotrader/model"
+
+// Strategy reacts to market data replayed by a Backtest (or, live, by a
+// matching REST/websocket-driven runner). Implementations only need to
+// handle the callbacks they care about.
+type Strategy interface {
+	OnKline(pair model.CurrencyPair, kline *model.KlineData)
+	OnTrade(pair model.CurrencyPair, trade *model.Trade)
+	OnBook(pair model.CurrencyPair, book *model.OrderBook)
+}
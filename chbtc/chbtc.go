@@ -6,11 +6,12 @@ import (
 	"crypto/md5"
 	"crypto/sha1"
 	"encoding/hex"
-	"io/ioutil"
-	"net/http"
+	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/Akagi201/cryptotrader/exchange"
+	"github.com/Akagi201/cryptotrader/internal/httpclient"
 	"github.com/Akagi201/cryptotrader/model"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
@@ -19,34 +20,69 @@ import (
 const (
 	MarketAPI = "http://api.chbtc.com/data/v1/"
 	TradeAPI  = "https://trade.chbtc.com/api/"
+
+	// Name is the exchange name CHBTC registers itself under.
+	Name = "chbtc"
+
+	// publicRPS and privateRPS are conservative defaults for CHBTC's
+	// published rate limits; callers needing different limits should go
+	// through the builder.
+	publicRPS  = 10
+	privateRPS = 5
 )
 
+func init() {
+	exchange.Register(Name, func(cfg *exchange.Config) exchange.Exchange {
+		return newFromConfig(cfg)
+	})
+}
+
 type CHBTC struct {
 	AccessKey string
 	SecretKey string
+
+	publicClient  httpclient.Client
+	privateClient httpclient.Client
 }
 
 func New(accessKey string, secretKey string) *CHBTC {
+	return newFromConfig(&exchange.Config{APIKey: accessKey, SecretKey: secretKey})
+}
+
+func newFromConfig(cfg *exchange.Config) *CHBTC {
+	var opts []httpclient.Option
+	if cfg.HTTPTimeout > 0 {
+		opts = append(opts, httpclient.WithTimeout(cfg.HTTPTimeout))
+	}
+	if cfg.HTTPProxy != "" {
+		opts = append(opts, httpclient.WithProxy(cfg.HTTPProxy))
+	}
+	if cfg.HTTPLib != "" {
+		opts = append(opts, httpclient.WithLib(cfg.HTTPLib))
+	}
+
 	return &CHBTC{
-		AccessKey: accessKey,
-		SecretKey: secretKey,
+		AccessKey:     cfg.APIKey,
+		SecretKey:     cfg.SecretKey,
+		publicClient:  httpclient.New(append(opts, httpclient.WithRateLimit(publicRPS))...),
+		privateClient: httpclient.New(append(opts, httpclient.WithRateLimit(privateRPS))...),
 	}
 }
 
+// GetName returns the exchange name, satisfying exchange.Exchange.
+func (cb *CHBTC) GetName() string {
+	return Name
+}
+
 // GetTicker 行情
-func (cb *CHBTC) GetTicker(base string, quote string) (*model.Ticker, error) {
-	log.Debugf("Currency base: %s, quote: %s", base, quote)
+func (cb *CHBTC) GetTicker(pair model.CurrencyPair) (*model.Ticker, error) {
+	log.Debugf("Currency pair: %v", pair)
 
-	url := MarketAPI + "ticker?currency=" + quote + "_" + base
+	url := MarketAPI + "ticker?currency=" + pair.String()
 
 	log.Debugf("Request url: %v", url)
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := cb.publicClient.Do("GET", url, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -107,17 +143,12 @@ func (cb *CHBTC) GetTicker(base string, quote string) (*model.Ticker, error) {
 // eth_cny: 可选 0.5, 0.3, 0.1
 // etc_cny: 可选 0.3, 0.1
 // bts_cny: 可选 1, 0.1
-func (cb *CHBTC) GetOrderBook(base string, quote string, size int, merge float64) (*model.OrderBook, error) {
-	url := MarketAPI + "depth?currency=" + quote + "_" + base + "&size=" + strconv.Itoa(size) + "&merge=" + strconv.FormatFloat(merge, 'f', -1, 64)
+func (cb *CHBTC) GetOrderBook(pair model.CurrencyPair, size int, merge float64) (*model.OrderBook, error) {
+	url := MarketAPI + "depth?currency=" + pair.String() + "&size=" + strconv.Itoa(size) + "&merge=" + strconv.FormatFloat(merge, 'f', -1, 64)
 
 	log.Debugf("Request url: %v", url)
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := cb.publicClient.Do("GET", url, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -125,8 +156,8 @@ func (cb *CHBTC) GetOrderBook(base string, quote string, size int, merge float64
 	log.Debugf("Response body: %v", string(body))
 
 	orderBook := &model.OrderBook{
-		Base:  base,
-		Quote: quote,
+		Base:  pair.Base,
+		Quote: pair.Quote,
 		Time:  time.Unix(gjson.GetBytes(body, "timestamp").Int(), 0),
 	}
 
@@ -159,18 +190,13 @@ func (cb *CHBTC) GetOrderBook(base string, quote string, size int, merge float64
 // etc_cny: ETC币/人民币
 // bts_cny: BTS币/人民币
 // since: 从指定交易 ID 后 50 条数据
-func (cb *CHBTC) GetTrades(base string, quote string, since int) (*model.Trades, error) {
-	url := MarketAPI + "trades?currency=" + quote + "_" + base
+func (cb *CHBTC) GetTrades(pair model.CurrencyPair, since int) (*model.Trades, error) {
+	url := MarketAPI + "trades?currency=" + pair.String()
 	if since != 0 {
 		url += "&since=" + strconv.Itoa(since)
 	}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := cb.publicClient.Do("GET", url, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -202,43 +228,29 @@ func (cb *CHBTC) GetTrades(base string, quote string, since int) (*model.Trades,
 // eth_cny: 以太币/人民币
 // etc_cny: ETC币/人民币
 // bts_cny: BTS币/人民币
-// typ:
-// 1min: 1 分钟
-// 3min: 3 分钟
-// 5min: 5 分钟
-// 15min: 15 分钟
-// 30min: 30 分钟
-// 1day: 1 日
-// 3day: 3 日
-// 1week: 1 周
-// 1hour: 1 小时
-// 2hour: 2 小时
-// 4hour: 4 小时
-// 6hour: 6小时
-// 12hour: 12 小时
-// since: 从这个时间戳之后的
-// size: 返回数据的条数限制(默认为 1000, 如果返回数据多于 1000 条, 那么只返回 1000 条)
-func (cb *CHBTC) GetKline(base string, quote string, typ string, since int, size int) (*model.Kline, error) {
-	url := MarketAPI + "kline?currency=" + quote + "_" + base
-
-	if len(typ) != 0 {
-		url += "&type=" + typ
+// opts: 可选参数, 目前支持 "since" (int64, 毫秒时间戳)
+// size: 返回数据的条数限制(默认为 1000, 如果返回数据多于 1000 条, 那么只返回 1000 条), 超过 1000 条请用 IterateKlines
+func (cb *CHBTC) GetKline(pair model.CurrencyPair, period model.KlinePeriod, size int, opts ...model.OptionalParameter) (*model.Kline, error) {
+	periodStr, err := periodString(period)
+	if err != nil {
+		return nil, err
 	}
+	url := MarketAPI + "kline?currency=" + pair.String() + "&type=" + periodStr
 
-	if since != 0 {
-		url += "&since=" + strconv.Itoa(since)
+	params := model.MergeOptionalParameters(opts)
+	if since, ok := params["since"]; ok {
+		sinceMillis, err := toInt64(since)
+		if err != nil {
+			return nil, fmt.Errorf("chbtc: invalid since parameter: %w", err)
+		}
+		url += "&since=" + strconv.FormatInt(sinceMillis, 10)
 	}
 
 	if size != 0 {
 		url += "&size=" + strconv.Itoa(size)
 	}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := cb.publicClient.Do("GET", url, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -300,12 +312,7 @@ func (cb *CHBTC) GetUserAddress(currency string) (string, error) {
 
 	url = TradeAPI + "getUserAddress?" + url
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := cb.privateClient.Do("GET", url, "", nil)
 	if err != nil {
 		return "", err
 	}
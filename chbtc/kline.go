@@ -0,0 +1,93 @@
+package chbtc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+// periodString maps a model.KlinePeriod to the "type" value CHBTC's kline
+// endpoint expects, erroring on a period CHBTC doesn't support rather
+// than silently querying 1min candles.
+func periodString(period model.KlinePeriod) (string, error) {
+	switch period {
+	case model.KLINE_1MIN:
+		return "1min", nil
+	case model.KLINE_3MIN:
+		return "3min", nil
+	case model.KLINE_5MIN:
+		return "5min", nil
+	case model.KLINE_15MIN:
+		return "15min", nil
+	case model.KLINE_30MIN:
+		return "30min", nil
+	case model.KLINE_1HOUR:
+		return "1hour", nil
+	case model.KLINE_2HOUR:
+		return "2hour", nil
+	case model.KLINE_4HOUR:
+		return "4hour", nil
+	case model.KLINE_6HOUR:
+		return "6hour", nil
+	case model.KLINE_12HOUR:
+		return "12hour", nil
+	case model.KLINE_1DAY:
+		return "1day", nil
+	case model.KLINE_3DAY:
+		return "3day", nil
+	case model.KLINE_1WEEK:
+		return "1week", nil
+	default:
+		return "", fmt.Errorf("chbtc: unsupported kline period %v", period)
+	}
+}
+
+// toInt64 coerces an OptionalParameter value to int64, erroring instead
+// of silently dropping the parameter when the caller passed something
+// that isn't a whole number.
+func toInt64(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case int64:
+		return n, nil
+	case int:
+		return int64(n), nil
+	default:
+		return 0, fmt.Errorf("chbtc: expected int64 or int, got %T", v)
+	}
+}
+
+// IterateKlines pages through GetKline's 1000-row-per-call limit and
+// returns every candle in [from, to].
+func (cb *CHBTC) IterateKlines(pair model.CurrencyPair, period model.KlinePeriod, from, to time.Time) ([]*model.KlineData, error) {
+	const pageSize = 1000
+
+	var all []*model.KlineData
+	since := from.UnixNano() / int64(time.Millisecond)
+
+	for {
+		kline, err := cb.GetKline(pair, period, pageSize, model.OptionalParameter{"since": since})
+		if err != nil {
+			return all, err
+		}
+		if len(kline.Data) == 0 {
+			break
+		}
+
+		for _, kd := range kline.Data {
+			if kd.Time.After(to) {
+				return all, nil
+			}
+			all = append(all, kd)
+		}
+
+		last := kline.Data[len(kline.Data)-1]
+		nextSince := last.Time.UnixNano()/int64(time.Millisecond) + 1
+		if nextSince <= since || len(kline.Data) < pageSize {
+			break
+		}
+		since = nextSince
+	}
+
+	return all, nil
+}
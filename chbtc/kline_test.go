@@ -0,0 +1,111 @@
+package chbtc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+func TestPeriodStringRejectsUnknownPeriod(t *testing.T) {
+	if _, err := periodString(model.KLINE_1MIN); err != nil {
+		t.Fatalf("periodString(KLINE_1MIN): %v", err)
+	}
+
+	if _, err := periodString(model.KlinePeriod(999)); err == nil {
+		t.Fatalf("want an error for an out-of-range KlinePeriod, got nil")
+	}
+}
+
+func TestToInt64RejectsNonIntegerTypes(t *testing.T) {
+	if got, err := toInt64(int64(5)); err != nil || got != 5 {
+		t.Fatalf("toInt64(int64(5)) = %v, %v", got, err)
+	}
+	if got, err := toInt64(5); err != nil || got != 5 {
+		t.Fatalf("toInt64(5) = %v, %v", got, err)
+	}
+	if _, err := toInt64("5"); err == nil {
+		t.Fatalf("want an error for a non-numeric since value, got nil")
+	}
+}
+
+// fakeKlineClient serves GetKline from an in-memory slice of candles,
+// paging by the "size" and "since" query parameters the way CHBTC's real
+// API would.
+type fakeKlineClient struct {
+	data []*model.KlineData
+}
+
+func (f *fakeKlineClient) Do(method, url, body string, headers map[string]string) ([]byte, error) {
+	var since int64
+	var size int
+	fmt.Sscanf(url, "http://api.chbtc.com/data/v1/kline?currency=cny_btc&type=1min&since=%d&size=%d", &since, &size)
+
+	var page []*model.KlineData
+	for _, kd := range f.data {
+		if kd.Time.UnixNano()/int64(time.Millisecond) < since {
+			continue
+		}
+		page = append(page, kd)
+		if len(page) >= size {
+			break
+		}
+	}
+
+	out := `{"moneyType":"btc","symbol":"btc_cny","data":[`
+	for i, kd := range page {
+		if i > 0 {
+			out += ","
+		}
+		out += fmt.Sprintf("[%d,%v,%v,%v,%v,%v]",
+			kd.Time.UnixNano()/int64(time.Millisecond), kd.Open, kd.High, kd.Low, kd.Close, kd.Amount)
+	}
+	out += `]}`
+
+	return []byte(out), nil
+}
+
+func TestIterateKlinesPagesAcrossLimit(t *testing.T) {
+	pair := model.CurrencyPair{Base: "btc", Quote: "cny"}
+
+	var data []*model.KlineData
+	for i := 0; i < 1500; i++ {
+		data = append(data, &model.KlineData{
+			Time: time.Unix(int64(i)*60, 0),
+		})
+	}
+
+	cb := &CHBTC{publicClient: &fakeKlineClient{data: data}}
+
+	from := data[0].Time
+	to := data[len(data)-1].Time
+
+	klines, err := cb.IterateKlines(pair, model.KLINE_1MIN, from, to)
+	if err != nil {
+		t.Fatalf("IterateKlines: %v", err)
+	}
+	if len(klines) != len(data) {
+		t.Fatalf("want every one of the %d candles paginated across the 1000-row limit, got %d", len(data), len(klines))
+	}
+}
+
+func TestIterateKlinesStopsAtTo(t *testing.T) {
+	pair := model.CurrencyPair{Base: "btc", Quote: "cny"}
+
+	var data []*model.KlineData
+	for i := 0; i < 100; i++ {
+		data = append(data, &model.KlineData{Time: time.Unix(int64(i)*60, 0)})
+	}
+
+	cb := &CHBTC{publicClient: &fakeKlineClient{data: data}}
+
+	to := data[49].Time
+	klines, err := cb.IterateKlines(pair, model.KLINE_1MIN, data[0].Time, to)
+	if err != nil {
+		t.Fatalf("IterateKlines: %v", err)
+	}
+	if len(klines) != 50 {
+		t.Fatalf("want candles truncated at `to`, got %d", len(klines))
+	}
+}
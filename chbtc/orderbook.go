@@ -0,0 +1,232 @@
+package chbtc
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Akagi201/cryptotrader/chbtc/ws"
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+// LocalOrderBook maintains a live order book in memory by applying the
+// websocket depth stream on top of an initial REST snapshot, instead of
+// polling GetOrderBook.
+type LocalOrderBook struct {
+	cb   *CHBTC
+	ws   *ws.Client
+	pair model.CurrencyPair
+
+	mu      sync.RWMutex
+	bids    map[float64]float64
+	asks    map[float64]float64
+	lastSeq int64
+
+	onUpdate func(*model.OrderBook)
+	sub      *ws.Subscription
+}
+
+// NewLocalOrderBook builds a LocalOrderBook for pair. wsClient must
+// already be connected; Start fetches the initial snapshot and begins
+// applying diffs.
+func NewLocalOrderBook(cb *CHBTC, wsClient *ws.Client, pair model.CurrencyPair) *LocalOrderBook {
+	return &LocalOrderBook{
+		cb:   cb,
+		ws:   wsClient,
+		pair: pair,
+		bids: make(map[float64]float64),
+		asks: make(map[float64]float64),
+	}
+}
+
+// OnUpdate registers fn to be called with a snapshot of the book after
+// every applied update.
+func (lob *LocalOrderBook) OnUpdate(fn func(*model.OrderBook)) {
+	lob.mu.Lock()
+	defer lob.mu.Unlock()
+	lob.onUpdate = fn
+}
+
+// Start takes the REST snapshot and subscribes to the depth stream.
+func (lob *LocalOrderBook) Start() error {
+	if err := lob.resnapshot(); err != nil {
+		return err
+	}
+
+	sub, err := lob.ws.SubscribeDepth(lob.pair, lob.handleUpdate)
+	if err != nil {
+		return err
+	}
+	lob.sub = sub
+
+	return nil
+}
+
+// Stop unsubscribes from the depth stream.
+func (lob *LocalOrderBook) Stop() {
+	if lob.sub != nil {
+		lob.sub.Unsubscribe()
+	}
+}
+
+func (lob *LocalOrderBook) handleUpdate(update *ws.DepthUpdate) {
+	lob.mu.Lock()
+
+	// lastSeq == 0 means "no baseline yet" (just resnapshotted), so the
+	// first push after a (re)snapshot always establishes the baseline
+	// rather than being treated as a gap.
+	if lob.lastSeq != 0 && update.Sequence != 0 && update.Sequence != lob.lastSeq+1 {
+		lob.mu.Unlock()
+		_ = lob.resnapshot()
+		return
+	}
+
+	lob.applyLocked(update.Book)
+	lob.lastSeq = update.Sequence
+	snapshot := lob.snapshotLocked()
+	onUpdate := lob.onUpdate
+	lob.mu.Unlock()
+
+	if onUpdate != nil {
+		onUpdate(snapshot)
+	}
+}
+
+func (lob *LocalOrderBook) applyLocked(book *model.OrderBook) {
+	for _, o := range book.Bids {
+		applyLevel(lob.bids, o)
+	}
+	for _, o := range book.Asks {
+		applyLevel(lob.asks, o)
+	}
+}
+
+func applyLevel(levels map[float64]float64, o *model.Order) {
+	if o.Amount == 0 {
+		delete(levels, o.Price)
+		return
+	}
+	levels[o.Price] = o.Amount
+}
+
+// resnapshot discards local state and refetches the REST snapshot, used
+// both on Start and after a detected sequence gap.
+func (lob *LocalOrderBook) resnapshot() error {
+	book, err := lob.cb.GetOrderBook(lob.pair, 50, 0)
+	if err != nil {
+		return err
+	}
+
+	lob.mu.Lock()
+	lob.bids = make(map[float64]float64, len(book.Bids))
+	lob.asks = make(map[float64]float64, len(book.Asks))
+	for _, o := range book.Bids {
+		lob.bids[o.Price] = o.Amount
+	}
+	for _, o := range book.Asks {
+		lob.asks[o.Price] = o.Amount
+	}
+	lob.lastSeq = 0
+	lob.mu.Unlock()
+
+	return nil
+}
+
+func (lob *LocalOrderBook) snapshotLocked() *model.OrderBook {
+	book := &model.OrderBook{Base: lob.pair.Base, Quote: lob.pair.Quote}
+	for price, amount := range lob.bids {
+		book.Bids = append(book.Bids, &model.Order{Price: price, Amount: amount})
+	}
+	for price, amount := range lob.asks {
+		book.Asks = append(book.Asks, &model.Order{Price: price, Amount: amount})
+	}
+	sort.Slice(book.Bids, func(i, j int) bool { return book.Bids[i].Price > book.Bids[j].Price })
+	sort.Slice(book.Asks, func(i, j int) bool { return book.Asks[i].Price < book.Asks[j].Price })
+	return book
+}
+
+// Best returns the highest bid and lowest ask currently in the book.
+func (lob *LocalOrderBook) Best() (bid, ask *model.Order) {
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+
+	for price, amount := range lob.bids {
+		if bid == nil || price > bid.Price {
+			bid = &model.Order{Price: price, Amount: amount}
+		}
+	}
+	for price, amount := range lob.asks {
+		if ask == nil || price < ask.Price {
+			ask = &model.Order{Price: price, Amount: amount}
+		}
+	}
+	return bid, ask
+}
+
+// Spread returns ask - bid.
+func (lob *LocalOrderBook) Spread() float64 {
+	bid, ask := lob.Best()
+	if bid == nil || ask == nil {
+		return 0
+	}
+	return ask.Price - bid.Price
+}
+
+// MidPrice returns the average of the best bid and ask.
+func (lob *LocalOrderBook) MidPrice() float64 {
+	bid, ask := lob.Best()
+	if bid == nil || ask == nil {
+		return 0
+	}
+	return (bid.Price + ask.Price) / 2
+}
+
+// VWAP walks price levels on side ("buy" consumes asks, "sell" consumes
+// bids) until amount is filled, returning the volume-weighted average
+// price. It returns an error if the book doesn't hold enough depth.
+func (lob *LocalOrderBook) VWAP(side string, amount float64) (float64, error) {
+	if amount <= 0 {
+		return 0, fmt.Errorf("chbtc: amount must be positive, got %v", amount)
+	}
+
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+
+	var levels map[float64]float64
+	ascending := true
+	if side == "buy" {
+		levels = lob.asks
+	} else {
+		levels = lob.bids
+		ascending = false
+	}
+
+	prices := make([]float64, 0, len(levels))
+	for price := range levels {
+		prices = append(prices, price)
+	}
+	if ascending {
+		sort.Float64s(prices)
+	} else {
+		sort.Sort(sort.Reverse(sort.Float64Slice(prices)))
+	}
+
+	var remaining, notional = amount, 0.0
+	for _, price := range prices {
+		if remaining <= 0 {
+			break
+		}
+		take := levels[price]
+		if take > remaining {
+			take = remaining
+		}
+		notional += take * price
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		return 0, fmt.Errorf("chbtc: not enough depth to fill %v", amount)
+	}
+
+	return notional / amount, nil
+}
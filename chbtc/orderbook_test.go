@@ -0,0 +1,159 @@
+package chbtc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Akagi201/cryptotrader/chbtc/ws"
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+type fakeOrderBookClient struct {
+	snapshots int
+}
+
+func (f *fakeOrderBookClient) Do(method, url, body string, headers map[string]string) ([]byte, error) {
+	f.snapshots++
+	return []byte(fmt.Sprintf(
+		`{"timestamp":0,"asks":[[101,1]],"bids":[[99,%d]]}`, f.snapshots)), nil
+}
+
+func newTestLocalOrderBook(client *fakeOrderBookClient) *LocalOrderBook {
+	pair := model.CurrencyPair{Base: "btc", Quote: "cny"}
+	cb := &CHBTC{publicClient: client}
+	return NewLocalOrderBook(cb, ws.NewClient(), pair)
+}
+
+func TestApplyLevelUpdatesAndRemoves(t *testing.T) {
+	levels := map[float64]float64{}
+
+	applyLevel(levels, &model.Order{Price: 100, Amount: 5})
+	if levels[100] != 5 {
+		t.Fatalf("want level inserted, got %v", levels[100])
+	}
+
+	applyLevel(levels, &model.Order{Price: 100, Amount: 3})
+	if levels[100] != 3 {
+		t.Fatalf("want level updated in place, got %v", levels[100])
+	}
+
+	applyLevel(levels, &model.Order{Price: 100, Amount: 0})
+	if _, ok := levels[100]; ok {
+		t.Fatalf("want a zero-amount update to remove the level")
+	}
+}
+
+func TestHandleUpdateEstablishesBaselineFromFirstPush(t *testing.T) {
+	client := &fakeOrderBookClient{}
+	lob := newTestLocalOrderBook(client)
+
+	if err := lob.resnapshot(); err != nil {
+		t.Fatalf("resnapshot: %v", err)
+	}
+
+	lob.handleUpdate(&ws.DepthUpdate{
+		Book:     &model.OrderBook{Bids: []*model.Order{{Price: 99, Amount: 2}}},
+		Sequence: 5,
+	})
+
+	if lob.lastSeq != 5 {
+		t.Fatalf("want the first push after a snapshot to set the baseline sequence, got %d", lob.lastSeq)
+	}
+	if client.snapshots != 1 {
+		t.Fatalf("want no extra resnapshot for the baseline-establishing push, got %d snapshots", client.snapshots)
+	}
+}
+
+func TestHandleUpdateDetectsGapAndResnapshots(t *testing.T) {
+	client := &fakeOrderBookClient{}
+	lob := newTestLocalOrderBook(client)
+
+	if err := lob.resnapshot(); err != nil {
+		t.Fatalf("resnapshot: %v", err)
+	}
+
+	lob.handleUpdate(&ws.DepthUpdate{
+		Book:     &model.OrderBook{Bids: []*model.Order{{Price: 99, Amount: 2}}},
+		Sequence: 5,
+	})
+	if client.snapshots != 1 {
+		t.Fatalf("want 1 snapshot after Start + the baseline push, got %d", client.snapshots)
+	}
+
+	// Sequence jumps from 5 to 10: a gap, should trigger a resnapshot
+	// instead of silently applying the diff on top of stale state.
+	lob.handleUpdate(&ws.DepthUpdate{
+		Book:     &model.OrderBook{Bids: []*model.Order{{Price: 98, Amount: 1}}},
+		Sequence: 10,
+	})
+
+	if client.snapshots != 2 {
+		t.Fatalf("want a sequence gap (5 -> 10) to trigger exactly one resnapshot, got %d snapshots", client.snapshots)
+	}
+	if lob.lastSeq != 0 {
+		t.Fatalf("want lastSeq reset to 0 by resnapshot so the next push re-establishes the baseline, got %d", lob.lastSeq)
+	}
+}
+
+func TestHandleUpdateAppliesContiguousSequence(t *testing.T) {
+	client := &fakeOrderBookClient{}
+	lob := newTestLocalOrderBook(client)
+
+	if err := lob.resnapshot(); err != nil {
+		t.Fatalf("resnapshot: %v", err)
+	}
+
+	lob.handleUpdate(&ws.DepthUpdate{
+		Book:     &model.OrderBook{Bids: []*model.Order{{Price: 99, Amount: 2}}},
+		Sequence: 1,
+	})
+	lob.handleUpdate(&ws.DepthUpdate{
+		Book:     &model.OrderBook{Bids: []*model.Order{{Price: 98, Amount: 4}}},
+		Sequence: 2,
+	})
+
+	if client.snapshots != 1 {
+		t.Fatalf("want no resnapshot for contiguous sequence numbers, got %d snapshots", client.snapshots)
+	}
+
+	bid, _ := lob.Best()
+	if bid == nil || bid.Price != 99 {
+		t.Fatalf("want both diffs merged into the book, best bid = %+v", bid)
+	}
+}
+
+func TestVWAPRejectsNonPositiveAmount(t *testing.T) {
+	client := &fakeOrderBookClient{}
+	lob := newTestLocalOrderBook(client)
+	if err := lob.resnapshot(); err != nil {
+		t.Fatalf("resnapshot: %v", err)
+	}
+
+	if _, err := lob.VWAP("buy", 0); err == nil {
+		t.Fatalf("want an error for amount=0 instead of a NaN/0 result")
+	}
+	if _, err := lob.VWAP("buy", -5); err == nil {
+		t.Fatalf("want an error for a negative amount")
+	}
+}
+
+func TestVWAPWalksLevels(t *testing.T) {
+	client := &fakeOrderBookClient{}
+	lob := newTestLocalOrderBook(client)
+	if err := lob.resnapshot(); err != nil {
+		t.Fatalf("resnapshot: %v", err)
+	}
+
+	// fakeOrderBookClient's snapshot has a single ask at 101 for amount 1.
+	price, err := lob.VWAP("buy", 1)
+	if err != nil {
+		t.Fatalf("VWAP: %v", err)
+	}
+	if price != 101 {
+		t.Fatalf("want vwap 101 filling the whole level, got %v", price)
+	}
+
+	if _, err := lob.VWAP("buy", 2); err == nil {
+		t.Fatalf("want an error when the book doesn't have enough depth to fill amount")
+	}
+}
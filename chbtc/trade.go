@@ -0,0 +1,251 @@
+package chbtc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Akagi201/cryptotrader/exchange"
+	"github.com/Akagi201/cryptotrader/model"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// OrderOption mutates the outgoing request params for PlaceOrder, mirroring
+// the goex LimitOrderOptionalParameter pattern.
+type OrderOption = exchange.OrderOption
+
+// PostOnly marks the order as maker-only.
+func PostOnly(params map[string]string) {
+	params["orderType"] = "postOnly"
+}
+
+// IOC marks the order as immediate-or-cancel.
+func IOC(params map[string]string) {
+	params["orderType"] = "ioc"
+}
+
+// FOK marks the order as fill-or-kill.
+func FOK(params map[string]string) {
+	params["orderType"] = "fok"
+}
+
+// APIError wraps the {code,message} error envelope CHBTC returns on
+// failed trade API calls.
+type APIError struct {
+	Code    int64
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("chbtc: code %d: %s", e.Code, e.Message)
+}
+
+// privateGet signs method+params and calls the trade API, returning the raw
+// body or an *APIError when the response carries an error envelope.
+func (cb *CHBTC) privateGet(method string, params map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	url := "method=" + method
+	url += "&accesskey=" + cb.AccessKey
+	for _, k := range keys {
+		url += "&" + k + "=" + params[k]
+	}
+	sign := cb.Sign(url)
+	url += "&sign=" + sign
+	url += "&reqTime=" + strconv.FormatInt(time.Now().UnixNano()/(int64(time.Millisecond)/int64(time.Nanosecond)), 10)
+
+	log.Debugf("Request url: %v", url)
+
+	body, err := cb.privateClient.Do("GET", TradeAPI+method+"?"+url, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Response body: %v", string(body))
+
+	if code := gjson.GetBytes(body, "code"); code.Exists() && code.Int() != 1000 {
+		return nil, &APIError{
+			Code:    code.Int(),
+			Message: gjson.GetBytes(body, "message").String(),
+		}
+	}
+
+	return body, nil
+}
+
+// PlaceOrder 下单
+func (cb *CHBTC) PlaceOrder(pair model.CurrencyPair, price, amount float64, side string, opts ...OrderOption) (*model.Order, error) {
+	tradeType := "1"
+	if side == "sell" {
+		tradeType = "0"
+	}
+
+	params := map[string]string{
+		"currency":  pair.String(),
+		"price":     strconv.FormatFloat(price, 'f', -1, 64),
+		"amount":    strconv.FormatFloat(amount, 'f', -1, 64),
+		"tradeType": tradeType,
+	}
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	body, err := cb.privateGet("order", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Order{
+		ID:     gjson.GetBytes(body, "id").String(),
+		Base:   pair.Base,
+		Quote:  pair.Quote,
+		Side:   side,
+		Price:  price,
+		Amount: amount,
+		Status: "pending",
+	}, nil
+}
+
+// CancelOrder 撤单
+func (cb *CHBTC) CancelOrder(pair model.CurrencyPair, id string) error {
+	params := map[string]string{
+		"currency": pair.String(),
+		"id":       id,
+	}
+
+	_, err := cb.privateGet("cancelOrder", params)
+	return err
+}
+
+// GetOrder 获取订单详情
+func (cb *CHBTC) GetOrder(pair model.CurrencyPair, id string) (*model.Order, error) {
+	params := map[string]string{
+		"currency": pair.String(),
+		"id":       id,
+	}
+
+	body, err := cb.privateGet("getOrder", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseOrder(body, pair), nil
+}
+
+// GetUnfinishedOrders 获取未完成订单
+func (cb *CHBTC) GetUnfinishedOrders(pair model.CurrencyPair) ([]*model.Order, error) {
+	params := map[string]string{
+		"currency": pair.String(),
+	}
+
+	body, err := cb.privateGet("getUnfinishedOrdersIgnoreTradeType", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []*model.Order
+	gjson.ParseBytes(body).ForEach(func(k, v gjson.Result) bool {
+		orders = append(orders, parseOrder([]byte(v.Raw), pair))
+		return true
+	})
+
+	return orders, nil
+}
+
+// GetOrderHistory 获取历史订单
+func (cb *CHBTC) GetOrderHistory(pair model.CurrencyPair, page, pageSize int) ([]*model.Order, error) {
+	params := map[string]string{
+		"currency":  pair.String(),
+		"pageIndex": strconv.Itoa(page),
+		"pageSize":  strconv.Itoa(pageSize),
+		"tradeType": "-1",
+	}
+
+	body, err := cb.privateGet("getOrdersHistory", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []*model.Order
+	gjson.ParseBytes(body).ForEach(func(k, v gjson.Result) bool {
+		orders = append(orders, parseOrder([]byte(v.Raw), pair))
+		return true
+	})
+
+	return orders, nil
+}
+
+// GetAccount 获取账户信息
+func (cb *CHBTC) GetAccount() (*model.Account, error) {
+	body, err := cb.privateGet("getAccountInfo", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &model.Account{
+		Balances: make(map[string]*model.Balance),
+	}
+
+	var parseErr error
+	gjson.GetBytes(body, "result.balance").ForEach(func(k, v gjson.Result) bool {
+		currency := k.String()
+		amount, err := strconv.ParseFloat(v.Get("amount").String(), 64)
+		if err != nil {
+			parseErr = err
+			return false
+		}
+		account.Balances[currency] = &model.Balance{
+			Currency:  currency,
+			Available: amount,
+		}
+		return true
+	})
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	gjson.GetBytes(body, "result.frozen").ForEach(func(k, v gjson.Result) bool {
+		currency := k.String()
+		amount, err := strconv.ParseFloat(v.Get("amount").String(), 64)
+		if err != nil {
+			parseErr = err
+			return false
+		}
+		if bal, ok := account.Balances[currency]; ok {
+			bal.Frozen = amount
+		} else {
+			account.Balances[currency] = &model.Balance{Currency: currency, Frozen: amount}
+		}
+		return true
+	})
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	return account, nil
+}
+
+func parseOrder(body []byte, pair model.CurrencyPair) *model.Order {
+	side := "buy"
+	if gjson.GetBytes(body, "type").Int() == 0 {
+		side = "sell"
+	}
+
+	return &model.Order{
+		ID:         gjson.GetBytes(body, "id").String(),
+		Base:       pair.Base,
+		Quote:      pair.Quote,
+		Side:       side,
+		Price:      gjson.GetBytes(body, "price").Float(),
+		Amount:     gjson.GetBytes(body, "total_amount").Float(),
+		DealAmount: gjson.GetBytes(body, "trade_amount").Float(),
+		Status:     gjson.GetBytes(body, "status").String(),
+		OrderTime:  time.Unix(gjson.GetBytes(body, "trade_date").Int()/1000, 0),
+	}
+}
@@ -0,0 +1,120 @@
+package chbtc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+type fakeTradeClient struct {
+	body []byte
+	err  error
+}
+
+func (f *fakeTradeClient) Do(method, url, body string, headers map[string]string) ([]byte, error) {
+	return f.body, f.err
+}
+
+func TestPrivateGetReturnsAPIErrorOnErrorEnvelope(t *testing.T) {
+	cb := &CHBTC{privateClient: &fakeTradeClient{
+		body: []byte(`{"code":1002,"message":"insufficient balance"}`),
+	}}
+
+	_, err := cb.privateGet("order", nil)
+	if err == nil {
+		t.Fatalf("want an error for a non-1000 code envelope, got nil")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("want *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Code != 1002 || apiErr.Message != "insufficient balance" {
+		t.Fatalf("want code 1002/%q, got code %d/%q", "insufficient balance", apiErr.Code, apiErr.Message)
+	}
+}
+
+func TestPrivateGetPassesThroughSuccessfulBody(t *testing.T) {
+	cb := &CHBTC{privateClient: &fakeTradeClient{
+		body: []byte(`{"code":1000,"id":"42"}`),
+	}}
+
+	body, err := cb.privateGet("order", nil)
+	if err != nil {
+		t.Fatalf("privateGet: %v", err)
+	}
+	if string(body) != `{"code":1000,"id":"42"}` {
+		t.Fatalf("want the raw body passed through on success, got %q", body)
+	}
+}
+
+func TestPrivateGetPropagatesTransportError(t *testing.T) {
+	wantErr := errors.New("connection refused")
+	cb := &CHBTC{privateClient: &fakeTradeClient{err: wantErr}}
+
+	if _, err := cb.privateGet("order", nil); !errors.Is(err, wantErr) {
+		t.Fatalf("want the transport error propagated unchanged, got %v", err)
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	pair := model.CurrencyPair{Base: "btc", Quote: "cny"}
+	body := []byte(`{"id":"7","type":1,"price":100.5,"total_amount":2,"trade_amount":1,"status":"2","trade_date":1000}`)
+
+	order := parseOrder(body, pair)
+
+	if order.ID != "7" {
+		t.Fatalf("want ID 7, got %q", order.ID)
+	}
+	if order.Side != "buy" {
+		t.Fatalf("want type=1 to parse as buy, got %q", order.Side)
+	}
+	if order.Price != 100.5 || order.Amount != 2 || order.DealAmount != 1 {
+		t.Fatalf("want price=100.5 amount=2 dealAmount=1, got price=%v amount=%v dealAmount=%v",
+			order.Price, order.Amount, order.DealAmount)
+	}
+	if !order.OrderTime.Equal(time.Unix(1, 0)) {
+		t.Fatalf("want trade_date millis converted to seconds, got %v", order.OrderTime)
+	}
+}
+
+func TestParseOrderSellSide(t *testing.T) {
+	pair := model.CurrencyPair{Base: "btc", Quote: "cny"}
+	body := []byte(`{"id":"8","type":0}`)
+
+	order := parseOrder(body, pair)
+	if order.Side != "sell" {
+		t.Fatalf("want type=0 to parse as sell, got %q", order.Side)
+	}
+}
+
+func TestGetAccountRejectsUnparsableAmount(t *testing.T) {
+	cb := &CHBTC{privateClient: &fakeTradeClient{
+		body: []byte(`{"code":1000,"result":{"balance":{"cny":{"amount":"not-a-number"}},"frozen":{}}}`),
+	}}
+
+	if _, err := cb.GetAccount(); err == nil {
+		t.Fatalf("want an error for a malformed balance amount instead of silently reporting 0")
+	}
+}
+
+func TestGetAccountMergesBalanceAndFrozen(t *testing.T) {
+	cb := &CHBTC{privateClient: &fakeTradeClient{
+		body: []byte(`{"code":1000,"result":{"balance":{"cny":{"amount":"100"}},"frozen":{"cny":{"amount":"25"}}}}`),
+	}}
+
+	account, err := cb.GetAccount()
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+
+	bal, ok := account.Balances["cny"]
+	if !ok {
+		t.Fatalf("want a cny balance entry")
+	}
+	if bal.Available != 100 || bal.Frozen != 25 {
+		t.Fatalf("want available=100 frozen=25, got available=%v frozen=%v", bal.Available, bal.Frozen)
+	}
+}
@@ -0,0 +1,325 @@
+// Package ws is CHBTC's websocket push-data client: tickers, depth and
+// trades delivered over wss://api.chbtc.com/websocket instead of REST
+// polling.
+package ws
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Akagi201/cryptotrader/model"
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// DefaultURL is CHBTC's public push-data endpoint.
+const DefaultURL = "wss://api.chbtc.com/websocket"
+
+const (
+	pingInterval = 20 * time.Second
+	readTimeout  = 45 * time.Second
+	reconnectMin = 1 * time.Second
+	reconnectMax = 30 * time.Second
+)
+
+type subKind int
+
+const (
+	kindTicker subKind = iota
+	kindDepth
+	kindTrades
+)
+
+type subscription struct {
+	id      int64
+	channel string
+	kind    subKind
+	ticker  func(*model.Ticker)
+	depth   func(*DepthUpdate)
+	trades  func([]*model.Trade)
+}
+
+// Subscription is a handle to a live push subscription.
+type Subscription struct {
+	client *Client
+	id     int64
+}
+
+// Unsubscribe stops delivering updates for this subscription.
+func (s *Subscription) Unsubscribe() {
+	s.client.unsubscribe(s.id)
+}
+
+// Client is a reconnecting CHBTC websocket push-data client.
+type Client struct {
+	url string
+
+	connMu  sync.Mutex
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+
+	subMu  sync.Mutex
+	subs   map[int64]*subscription
+	nextID int64
+
+	errCh  chan error
+	closed chan struct{}
+}
+
+// NewClient returns a Client pointed at DefaultURL. Call Connect to open
+// the socket.
+func NewClient() *Client {
+	return &Client{
+		url:    DefaultURL,
+		subs:   make(map[int64]*subscription),
+		errCh:  make(chan error, 16),
+		closed: make(chan struct{}),
+	}
+}
+
+// Errors surfaces connection and protocol errors; a reconnect is always
+// attempted in the background regardless of whether it is drained.
+func (c *Client) Errors() <-chan error {
+	return c.errCh
+}
+
+// Connect dials the websocket and starts the read and heartbeat loops.
+func (c *Client) Connect() error {
+	conn, _, err := websocket.DefaultDialer.Dial(c.url, nil)
+	if err != nil {
+		return err
+	}
+
+	c.connMu.Lock()
+	c.conn = conn
+	c.connMu.Unlock()
+
+	go c.readLoop()
+	go c.heartbeatLoop()
+
+	return nil
+}
+
+// Close shuts the client down for good; no further reconnect is attempted.
+func (c *Client) Close() error {
+	close(c.closed)
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// SubscribeTicker streams ticker updates for pair to fn until the
+// returned Subscription is unsubscribed.
+func (c *Client) SubscribeTicker(pair model.CurrencyPair, fn func(*model.Ticker)) (*Subscription, error) {
+	return c.subscribe(&subscription{
+		channel: channel(pair, "ticker"),
+		kind:    kindTicker,
+		ticker:  fn,
+	})
+}
+
+// SubscribeDepth streams order book updates for pair to fn until the
+// returned Subscription is unsubscribed.
+func (c *Client) SubscribeDepth(pair model.CurrencyPair, fn func(*DepthUpdate)) (*Subscription, error) {
+	return c.subscribe(&subscription{
+		channel: channel(pair, "depth"),
+		kind:    kindDepth,
+		depth:   fn,
+	})
+}
+
+// SubscribeTrades streams trade updates for pair to fn until the returned
+// Subscription is unsubscribed.
+func (c *Client) SubscribeTrades(pair model.CurrencyPair, fn func([]*model.Trade)) (*Subscription, error) {
+	return c.subscribe(&subscription{
+		channel: channel(pair, "trades"),
+		kind:    kindTrades,
+		trades:  fn,
+	})
+}
+
+func (c *Client) subscribe(sub *subscription) (*Subscription, error) {
+	sub.id = atomic.AddInt64(&c.nextID, 1)
+
+	c.subMu.Lock()
+	c.subs[sub.id] = sub
+	c.subMu.Unlock()
+
+	if err := c.send(addChannelMsg(sub.channel)); err != nil {
+		return nil, err
+	}
+
+	return &Subscription{client: c, id: sub.id}, nil
+}
+
+func (c *Client) unsubscribe(id int64) {
+	c.subMu.Lock()
+	sub, ok := c.subs[id]
+	delete(c.subs, id)
+	c.subMu.Unlock()
+
+	if ok {
+		_ = c.send(removeChannelMsg(sub.channel))
+	}
+}
+
+func channel(pair model.CurrencyPair, kind string) string {
+	return strings.ToLower(pair.Base+pair.Quote) + "_" + kind
+}
+
+func addChannelMsg(ch string) map[string]string {
+	return map[string]string{"event": "addChannel", "channel": ch}
+}
+
+func removeChannelMsg(ch string) map[string]string {
+	return map[string]string{"event": "removeChannel", "channel": ch}
+}
+
+func (c *Client) send(v interface{}) error {
+	c.connMu.Lock()
+	conn := c.conn
+	c.connMu.Unlock()
+	if conn == nil {
+		return nil
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return conn.WriteJSON(v)
+}
+
+func (c *Client) heartbeatLoop() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.closed:
+			return
+		case <-ticker.C:
+			_ = c.send(map[string]string{"event": "ping"})
+		}
+	}
+}
+
+func (c *Client) readLoop() {
+	for {
+		select {
+		case <-c.closed:
+			return
+		default:
+		}
+
+		c.connMu.Lock()
+		conn := c.conn
+		c.connMu.Unlock()
+
+		conn.SetReadDeadline(time.Now().Add(readTimeout))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			c.emitErr(err)
+			if !c.reconnect() {
+				return
+			}
+			continue
+		}
+
+		c.handleFrame(data)
+	}
+}
+
+func (c *Client) handleFrame(data []byte) {
+	payload, err := GzipDecompress(data)
+	if err != nil {
+		// CHBTC's own pong/ack frames are sometimes sent uncompressed.
+		payload = data
+	}
+
+	ch := gjson.GetBytes(payload, "channel").String()
+	if ch == "" {
+		return
+	}
+
+	c.subMu.Lock()
+	var matches []*subscription
+	for _, sub := range c.subs {
+		if sub.channel == ch {
+			matches = append(matches, sub)
+		}
+	}
+	c.subMu.Unlock()
+
+	for _, sub := range matches {
+		sub := sub
+		switch sub.kind {
+		case kindTicker:
+			go sub.ticker(parseTicker(payload))
+		case kindDepth:
+			go sub.depth(parseDepth(payload))
+		case kindTrades:
+			go sub.trades(parseTrades(payload))
+		}
+	}
+}
+
+func (c *Client) emitErr(err error) {
+	select {
+	case c.errCh <- err:
+	default:
+		log.Debugf("ws: error channel full, dropping: %v", err)
+	}
+}
+
+// reconnect redials with exponential backoff and resubscribes every
+// tracked channel. It returns false if the client was closed meanwhile.
+func (c *Client) reconnect() bool {
+	backoff := reconnectMin
+
+	for {
+		select {
+		case <-c.closed:
+			return false
+		default:
+		}
+
+		conn, _, err := websocket.DefaultDialer.Dial(c.url, nil)
+		if err != nil {
+			c.emitErr(err)
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > reconnectMax {
+				backoff = reconnectMax
+			}
+			continue
+		}
+
+		c.connMu.Lock()
+		c.conn = conn
+		c.connMu.Unlock()
+
+		c.resubscribeAll()
+		return true
+	}
+}
+
+func (c *Client) resubscribeAll() {
+	c.subMu.Lock()
+	channels := make([]string, 0, len(c.subs))
+	for _, sub := range c.subs {
+		channels = append(channels, sub.channel)
+	}
+	c.subMu.Unlock()
+
+	for _, ch := range channels {
+		if err := c.send(addChannelMsg(ch)); err != nil {
+			c.emitErr(err)
+		}
+	}
+}
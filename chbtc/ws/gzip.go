@@ -0,0 +1,19 @@
+package ws
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+)
+
+// GzipDecompress inflates a gzip-compressed websocket frame, as CHBTC (and
+// most of its goex-style peers) sends push data compressed.
+func GzipDecompress(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
@@ -0,0 +1,73 @@
+package ws
+
+import (
+	"time"
+
+	"github.com/Akagi201/cryptotrader/model"
+	"github.com/tidwall/gjson"
+)
+
+func parseTicker(payload []byte) *model.Ticker {
+	t := gjson.GetBytes(payload, "ticker")
+	return &model.Ticker{
+		Buy:  t.Get("buy").Float(),
+		Sell: t.Get("sell").Float(),
+		Last: t.Get("last").Float(),
+		Low:  t.Get("low").Float(),
+		High: t.Get("high").Float(),
+		Vol:  t.Get("vol").Float(),
+	}
+}
+
+// DepthUpdate is one push on the depth channel: a set of price levels to
+// merge into a local book (Amount 0 means "remove this level"), tagged
+// with the server's sequence number so gaps can be detected.
+type DepthUpdate struct {
+	Book     *model.OrderBook
+	Sequence int64
+}
+
+func parseDepth(payload []byte) *DepthUpdate {
+	book := &model.OrderBook{
+		Time: time.Unix(gjson.GetBytes(payload, "timestamp").Int(), 0),
+	}
+
+	gjson.GetBytes(payload, "asks").ForEach(func(_, v gjson.Result) bool {
+		book.Asks = append(book.Asks, &model.Order{
+			Price:  v.Array()[0].Float(),
+			Amount: v.Array()[1].Float(),
+		})
+		return true
+	})
+
+	gjson.GetBytes(payload, "bids").ForEach(func(_, v gjson.Result) bool {
+		book.Bids = append(book.Bids, &model.Order{
+			Price:  v.Array()[0].Float(),
+			Amount: v.Array()[1].Float(),
+		})
+		return true
+	})
+
+	return &DepthUpdate{
+		Book:     book,
+		Sequence: gjson.GetBytes(payload, "sequence").Int(),
+	}
+}
+
+func parseTrades(payload []byte) []*model.Trade {
+	var trades []*model.Trade
+
+	gjson.GetBytes(payload, "trades").ForEach(func(_, v gjson.Result) bool {
+		trades = append(trades, &model.Trade{
+			Amount:    v.Get("amount").Float(),
+			Price:     v.Get("price").Float(),
+			Tid:       v.Get("tid").Int(),
+			TradeType: v.Get("trade_type").String(),
+			Type:      v.Get("type").String(),
+			Date:      time.Unix(v.Get("date").Int(), 0),
+		})
+		return true
+	})
+
+	return trades
+}
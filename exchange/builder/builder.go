@@ -0,0 +1,57 @@
+// Package builder provides a fluent way to assemble the Config an
+// exchange.Exchange is built from.
+package builder
+
+import (
+	"time"
+
+	"github.com/Akagi201/cryptotrader/exchange"
+)
+
+// APIBuilder accumulates connection settings before building a named
+// exchange.Exchange.
+type APIBuilder struct {
+	cfg exchange.Config
+}
+
+// New returns an empty APIBuilder.
+func New() *APIBuilder {
+	return &APIBuilder{}
+}
+
+// APIKey sets the exchange API key.
+func (b *APIBuilder) APIKey(key string) *APIBuilder {
+	b.cfg.APIKey = key
+	return b
+}
+
+// SecretKey sets the exchange secret key.
+func (b *APIBuilder) SecretKey(key string) *APIBuilder {
+	b.cfg.SecretKey = key
+	return b
+}
+
+// HTTPTimeout sets the timeout used for outgoing HTTP requests.
+func (b *APIBuilder) HTTPTimeout(timeout time.Duration) *APIBuilder {
+	b.cfg.HTTPTimeout = timeout
+	return b
+}
+
+// HTTPProxy sets a proxy URL (http:// or socks5://) to route requests
+// through.
+func (b *APIBuilder) HTTPProxy(proxy string) *APIBuilder {
+	b.cfg.HTTPProxy = proxy
+	return b
+}
+
+// HTTPLib selects the HTTP transport, e.g. "fasthttp". Leaving it unset
+// falls back to the HTTP_LIB env var, then net/http.
+func (b *APIBuilder) HTTPLib(lib string) *APIBuilder {
+	b.cfg.HTTPLib = lib
+	return b
+}
+
+// Build constructs the named exchange from the accumulated settings.
+func (b *APIBuilder) Build(name string) (exchange.Exchange, error) {
+	return exchange.New(name, &b.cfg)
+}
@@ -0,0 +1,65 @@
+// Package exchange defines the vendor-agnostic trading interface and a
+// registry that lets callers build a concrete exchange implementation by
+// name, so strategies can be written once and pointed at any registered
+// exchange.
+package exchange
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+// OrderOption mutates the outgoing order params, letting callers opt into
+// exchange-specific order flags (post-only, IOC, FOK, ...) without widening
+// PlaceOrder's signature.
+type OrderOption func(params map[string]string)
+
+// Exchange is implemented by every vendored exchange package.
+type Exchange interface {
+	GetName() string
+	GetTicker(pair model.CurrencyPair) (*model.Ticker, error)
+	GetOrderBook(pair model.CurrencyPair, size int, merge float64) (*model.OrderBook, error)
+	GetTrades(pair model.CurrencyPair, since int) (*model.Trades, error)
+	GetKline(pair model.CurrencyPair, period model.KlinePeriod, size int, opts ...model.OptionalParameter) (*model.Kline, error)
+	PlaceOrder(pair model.CurrencyPair, price, amount float64, side string, opts ...OrderOption) (*model.Order, error)
+	CancelOrder(pair model.CurrencyPair, id string) error
+	GetAccount() (*model.Account, error)
+}
+
+// Config carries the connection settings a Factory needs to build an
+// Exchange.
+type Config struct {
+	APIKey      string
+	SecretKey   string
+	HTTPTimeout time.Duration
+	HTTPProxy   string
+	// HTTPLib selects the HTTP transport ("" for net/http, "fasthttp" for
+	// valyala/fasthttp). Falls back to the HTTP_LIB env var when empty.
+	HTTPLib string
+}
+
+// Factory constructs an Exchange from Config. Exchange packages register a
+// Factory under their name via Register, typically from an init func.
+type Factory func(cfg *Config) Exchange
+
+var factories = make(map[string]Factory)
+
+// Register adds a named exchange implementation to the registry. It panics
+// on duplicate registration, mirroring the database/sql driver pattern.
+func Register(name string, factory Factory) {
+	if _, exists := factories[name]; exists {
+		panic("exchange: Register called twice for exchange " + name)
+	}
+	factories[name] = factory
+}
+
+// New builds the named exchange from cfg.
+func New(name string, cfg *Config) (Exchange, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("exchange: no such exchange %q", name)
+	}
+	return factory(cfg), nil
+}
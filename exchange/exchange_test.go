@@ -0,0 +1,65 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/Akagi201/cryptotrader/model"
+)
+
+// stubExchange is a minimal Exchange implementation for exercising the
+// registry, not any particular exchange's behavior.
+type stubExchange struct{ name string }
+
+func (s *stubExchange) GetName() string { return s.name }
+func (s *stubExchange) GetTicker(pair model.CurrencyPair) (*model.Ticker, error) {
+	return nil, nil
+}
+func (s *stubExchange) GetOrderBook(pair model.CurrencyPair, size int, merge float64) (*model.OrderBook, error) {
+	return nil, nil
+}
+func (s *stubExchange) GetTrades(pair model.CurrencyPair, since int) (*model.Trades, error) {
+	return nil, nil
+}
+func (s *stubExchange) GetKline(pair model.CurrencyPair, period model.KlinePeriod, size int, opts ...model.OptionalParameter) (*model.Kline, error) {
+	return nil, nil
+}
+func (s *stubExchange) PlaceOrder(pair model.CurrencyPair, price, amount float64, side string, opts ...OrderOption) (*model.Order, error) {
+	return nil, nil
+}
+func (s *stubExchange) CancelOrder(pair model.CurrencyPair, id string) error { return nil }
+func (s *stubExchange) GetAccount() (*model.Account, error)                  { return nil, nil }
+
+var _ Exchange = (*stubExchange)(nil)
+
+func TestRegisterPanicsOnDuplicateName(t *testing.T) {
+	name := "test-exchange-duplicate"
+	Register(name, func(cfg *Config) Exchange { return &stubExchange{name: name} })
+	defer delete(factories, name)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("want Register to panic on a duplicate name")
+		}
+	}()
+	Register(name, func(cfg *Config) Exchange { return &stubExchange{name: name} })
+}
+
+func TestNewReturnsErrorForUnknownExchange(t *testing.T) {
+	if _, err := New("no-such-exchange", &Config{}); err == nil {
+		t.Fatalf("want an error for an unregistered exchange name")
+	}
+}
+
+func TestNewBuildsRegisteredExchange(t *testing.T) {
+	name := "test-exchange-known"
+	Register(name, func(cfg *Config) Exchange { return &stubExchange{name: name} })
+	defer delete(factories, name)
+
+	ex, err := New(name, &Config{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if ex.GetName() != name {
+		t.Fatalf("want the registered factory's exchange, got name %q", ex.GetName())
+	}
+}
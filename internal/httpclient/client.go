@@ -0,0 +1,94 @@
+// Package httpclient provides the pluggable HTTP transport used by the
+// vendored exchange packages: a net/http implementation and a fasthttp
+// implementation (selected via the HTTP_LIB env var or WithLib), wrapped
+// with rate limiting and retry-with-backoff so exchange packages don't
+// have to reimplement either.
+package httpclient
+
+import (
+	"os"
+	"time"
+)
+
+// Client issues a single HTTP request and returns the response body.
+type Client interface {
+	Do(method, url, body string, headers map[string]string) ([]byte, error)
+}
+
+// LibFastHTTP selects the valyala/fasthttp backed Client, either via
+// WithLib or the HTTP_LIB env var.
+const LibFastHTTP = "fasthttp"
+
+type options struct {
+	lib        string
+	timeout    time.Duration
+	proxy      string
+	rps        float64
+	maxRetries int
+}
+
+func defaultOptions() options {
+	return options{
+		timeout:    10 * time.Second,
+		rps:        10,
+		maxRetries: 3,
+	}
+}
+
+// Option configures a Client built by New.
+type Option func(*options)
+
+// WithLib explicitly selects the transport ("" or "net/http" for the
+// standard library, "fasthttp" for valyala/fasthttp). Overrides HTTP_LIB.
+func WithLib(lib string) Option {
+	return func(o *options) { o.lib = lib }
+}
+
+// WithTimeout sets the per-request timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithProxy routes requests through proxyURL, which may be an http://,
+// https:// or socks5:// URL.
+func WithProxy(proxyURL string) Option {
+	return func(o *options) { o.proxy = proxyURL }
+}
+
+// WithRateLimit caps outgoing requests to rps requests per second.
+func WithRateLimit(rps float64) Option {
+	return func(o *options) { o.rps = rps }
+}
+
+// WithMaxRetries sets how many times a request is retried on a 5xx
+// response or network error, with exponential backoff between attempts.
+func WithMaxRetries(n int) Option {
+	return func(o *options) { o.maxRetries = n }
+}
+
+// New builds a Client from opts. The transport is net/http unless
+// HTTP_LIB=fasthttp is set in the environment or WithLib selects
+// LibFastHTTP.
+func New(opts ...Option) Client {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	lib := o.lib
+	if lib == "" {
+		lib = os.Getenv("HTTP_LIB")
+	}
+
+	var client Client
+	if lib == LibFastHTTP {
+		client = newFastHTTPClient(o)
+	} else {
+		client = newNetHTTPClient(o)
+	}
+
+	client = withRetry(client, o.maxRetries)
+	client = withRateLimit(client, o.rps)
+
+	return client
+}
@@ -0,0 +1,13 @@
+package httpclient
+
+import "fmt"
+
+// StatusError is returned by a Client when the server responds with a 5xx
+// status, so withRetry can distinguish it from a network-level error.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("httpclient: server error, status %d", e.StatusCode)
+}
@@ -0,0 +1,66 @@
+package httpclient
+
+import (
+	"net/url"
+
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpproxy"
+)
+
+type fastHTTPClient struct {
+	client *fasthttp.Client
+}
+
+func newFastHTTPClient(o options) *fastHTTPClient {
+	client := &fasthttp.Client{
+		ReadTimeout:  o.timeout,
+		WriteTimeout: o.timeout,
+	}
+
+	if o.proxy != "" {
+		client.Dial = proxyDialer(o.proxy)
+	}
+
+	return &fastHTTPClient{client: client}
+}
+
+// proxyDialer picks the fasthttpproxy dialer matching proxy's scheme:
+// FasthttpSocksDialer for socks5://, FasthttpHTTPDialer otherwise. Both
+// dialers take a bare host:port, so the scheme is stripped for SOCKS5;
+// the HTTP dialer is left exactly as it was called before so existing
+// HTTP-proxy configs keep working unchanged.
+func proxyDialer(proxy string) fasthttp.DialFunc {
+	if u, err := url.Parse(proxy); err == nil && u.Scheme == "socks5" {
+		return fasthttpproxy.FasthttpSocksDialer(u.Host)
+	}
+
+	return fasthttpproxy.FasthttpHTTPDialer(proxy)
+}
+
+func (c *fastHTTPClient) Do(method, url, body string, headers map[string]string) ([]byte, error) {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod(method)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if body != "" {
+		req.SetBodyString(body)
+	}
+
+	if err := c.client.Do(req, resp); err != nil {
+		return nil, err
+	}
+
+	respBody := append([]byte(nil), resp.Body()...)
+
+	if resp.StatusCode() >= 500 {
+		return respBody, &StatusError{StatusCode: resp.StatusCode()}
+	}
+
+	return respBody, nil
+}
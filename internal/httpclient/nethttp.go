@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type netHTTPClient struct {
+	client *http.Client
+}
+
+func newNetHTTPClient(o options) *netHTTPClient {
+	transport := &http.Transport{}
+
+	if o.proxy != "" {
+		if proxyURL, err := url.Parse(o.proxy); err == nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	return &netHTTPClient{
+		client: &http.Client{
+			Timeout:   o.timeout,
+			Transport: transport,
+		},
+	}
+}
+
+func (c *netHTTPClient) Do(method, rawURL, body string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequest(method, rawURL, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 500 {
+		return respBody, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	return respBody, nil
+}
@@ -0,0 +1,65 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket is a minimal token-bucket limiter: it refills at rps tokens
+// per second, up to a burst of one second's worth, and blocks callers
+// until a token is available.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rps      float64
+	tokens   float64
+	burst    float64
+	lastFill time.Time
+}
+
+func newTokenBucket(rps float64) *tokenBucket {
+	if rps <= 0 {
+		rps = 1
+	}
+	return &tokenBucket{
+		rps:      rps,
+		tokens:   rps,
+		burst:    rps,
+		lastFill: time.Now(),
+	}
+}
+
+func (b *tokenBucket) take() {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.lastFill).Seconds() * b.rps
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.lastFill = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.rps * float64(time.Second))
+		b.mu.Unlock()
+		time.Sleep(wait)
+	}
+}
+
+type rateLimitedClient struct {
+	Client
+	limiter *tokenBucket
+}
+
+func withRateLimit(c Client, rps float64) Client {
+	return &rateLimitedClient{Client: c, limiter: newTokenBucket(rps)}
+}
+
+func (c *rateLimitedClient) Do(method, url, body string, headers map[string]string) ([]byte, error) {
+	c.limiter.take()
+	return c.Client.Do(method, url, body, headers)
+}
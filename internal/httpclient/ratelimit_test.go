@@ -0,0 +1,31 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketLimitsBurst(t *testing.T) {
+	b := newTokenBucket(10)
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		b.take()
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("want the initial burst of 10 tokens to drain without waiting, took %v", elapsed)
+	}
+
+	start = time.Now()
+	b.take()
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("want take() to block for a refill once the burst is exhausted, took %v", elapsed)
+	}
+}
+
+func TestNewTokenBucketRejectsNonPositiveRate(t *testing.T) {
+	b := newTokenBucket(0)
+	if b.rps != 1 {
+		t.Fatalf("want a non-positive rps to fall back to 1, got %v", b.rps)
+	}
+}
@@ -0,0 +1,39 @@
+package httpclient
+
+import "time"
+
+type retryingClient struct {
+	Client
+	maxRetries int
+}
+
+func withRetry(c Client, maxRetries int) Client {
+	if maxRetries <= 0 {
+		return c
+	}
+	return &retryingClient{Client: c, maxRetries: maxRetries}
+}
+
+// Do retries on network errors and 5xx responses, backing off
+// exponentially (100ms, 200ms, 400ms, ...) between attempts.
+func (c *retryingClient) Do(method, url, body string, headers map[string]string) ([]byte, error) {
+	backoff := 100 * time.Millisecond
+
+	var respBody []byte
+	var err error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		respBody, err = c.Client.Do(method, url, body, headers)
+		if err == nil {
+			return respBody, nil
+		}
+
+		if attempt == c.maxRetries {
+			break
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	return respBody, err
+}
@@ -0,0 +1,128 @@
+// Package model holds the exchange-agnostic data types shared by the
+// vendored exchange packages.
+package model
+
+import "time"
+
+// CurrencyPair is the canonical (base, quote) pair used across exchange
+// implementations, e.g. {Base: "btc", Quote: "cny"}.
+type CurrencyPair struct {
+	Base  string
+	Quote string
+}
+
+// String renders the pair the way CHBTC (and most of its peers) expect it
+// on the wire: quote_base.
+func (p CurrencyPair) String() string {
+	return p.Quote + "_" + p.Base
+}
+
+// Ticker 行情
+type Ticker struct {
+	Buy  float64
+	Sell float64
+	Last float64
+	Low  float64
+	High float64
+	Vol  float64
+}
+
+// Order 订单/深度挂单
+type Order struct {
+	ID         string
+	Base       string
+	Quote      string
+	Side       string
+	Price      float64
+	Amount     float64
+	DealAmount float64
+	Status     string
+	OrderTime  time.Time
+}
+
+// OrderBook 市场深度
+type OrderBook struct {
+	Base  string
+	Quote string
+	Time  time.Time
+	Asks  []*Order
+	Bids  []*Order
+}
+
+// Trade 成交记录
+type Trade struct {
+	Amount    float64
+	Price     float64
+	Tid       int64
+	TradeType string
+	Type      string
+	Date      time.Time
+}
+
+// Trades 成交记录列表
+type Trades []*Trade
+
+// KlineData 单根 K 线数据
+type KlineData struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Amount float64
+}
+
+// Kline K 线数据
+type Kline struct {
+	MoneyType string
+	Symbol    string
+	Data      []*KlineData
+}
+
+// KlinePeriod is an exchange-agnostic candlestick interval. Each exchange
+// package maps it to its own wire representation (e.g. CHBTC's "1min").
+type KlinePeriod int
+
+const (
+	KLINE_1MIN KlinePeriod = iota
+	KLINE_3MIN
+	KLINE_5MIN
+	KLINE_15MIN
+	KLINE_30MIN
+	KLINE_1HOUR
+	KLINE_2HOUR
+	KLINE_4HOUR
+	KLINE_6HOUR
+	KLINE_12HOUR
+	KLINE_1DAY
+	KLINE_3DAY
+	KLINE_1WEEK
+)
+
+// OptionalParameter carries optional, exchange-specific request args (e.g.
+// "since", "endTime") without widening every call's signature.
+type OptionalParameter map[string]interface{}
+
+// MergeOptionalParameters flattens a list of OptionalParameter into one
+// map, later entries overriding earlier ones on key collision.
+func MergeOptionalParameters(opts []OptionalParameter) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, opt := range opts {
+		for k, v := range opt {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// Balance 账户单一币种余额
+type Balance struct {
+	Currency  string
+	Available float64
+	Frozen    float64
+}
+
+// Account 账户信息
+type Account struct {
+	Balances map[string]*Balance
+}